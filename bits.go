@@ -9,9 +9,16 @@ const (
 	RealFLI   int64 = MaxFLI - FLIOffset // 30 - 6 = 24 bits
 )
 
-// Bit flags representing the block usage state
-// If the 0th bit of the block size is 1, the block is unused; if 0, the block is in use
-// If the 1st bit of the block size is 1, the previous block is unused; if 0, the previous block is in use
+// Bit flags representing the block usage state, packed into the low bits of BlockHeader.blockSize:
+//   - bit 0 (FreeBlock/UsedBlock): whether the block itself is free.
+//   - bit 1 (PreviousBlockFree/PreviousBlockUsed): whether the physically preceding block is free.
+//   - bit 2 (TinyChunk): whether the block backs the tiny-allocator tier rather than a single
+//     normal allocation (see tiny.go).
+//   - bits 32-63: stamped with the owning shard index by ConcurrentArena's tagShard (see
+//     concurrent.go); BlockSize only masks the low 32 bits, so these are otherwise unused.
+//
+// Any future flag must claim a bit outside this list, or it will silently collide with one of
+// the above.
 type blockStatus = int64
 
 const (
@@ -42,6 +49,31 @@ const (
 
 	// Threshold for small block size
 	SmallBlockSize int64 = 128
+
+	// TinyChunk marks a block as backing the tiny-allocator tier rather than a single normal
+	// allocation. It is stamped on the owning chunk's own BlockHeader; individual slots handed
+	// out of that chunk tag it into a back-pointer ahead of their data instead, since a slot's
+	// address is not reliably BlockHeaderSize bytes past a real BlockHeader.
+	TinyChunk int64 = 0x1 << 2
+
+	// TinyThreshold is the request size, in bytes, from which the tiny-block sub-allocator tier
+	// is no longer used; requests below it are bump-allocated instead of rounded up to
+	// MinBlockSize and routed through the normal TLSF path.
+	TinyThreshold int64 = MinBlockSize
+
+	// tinyChunkSize is the size of one chunk obtained from the normal TLSF path to back the
+	// tiny-allocator tier.
+	tinyChunkSize int64 = 64
+
+	// tinySlotAlign is the alignment, in bytes, used for bump-allocated tiny slots, and also the
+	// size of the tagged back-pointer stored immediately ahead of each slot.
+	tinySlotAlign int64 = 8
+
+	// tinySlotSize16 is stamped into a tiny slot's back-pointer word, alongside TinyChunk, when
+	// that slot's reserved data area is tinySlotAlign*2 (16) bytes rather than the default 8.
+	// Chunk pointers are always at least BlockAlign-aligned, so bits 0-3 of the back-pointer are
+	// free for both tags to share.
+	tinySlotSize16 int64 = 0x1 << 3
 )
 
 var table = [256]int64{
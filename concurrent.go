@@ -0,0 +1,204 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import (
+	"arena"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// shardIDShift is the bit position at which a block's owning shard index is stamped into
+// BlockHeader.blockSize. BlockSize only masks the low 32 bits, so the high 32 bits of the
+// (64-bit) blockSize field are otherwise unused and safe to repurpose here.
+const shardIDShift = 32
+
+// ErrShardTooSmall is the panic value raised by NewConcurrentArena when bytes split evenly
+// across shards would leave a shard without enough room for its own sentinel head/tail headers.
+var ErrShardTooSmall = errors.New("tlsf: shard size too small")
+
+// ConcurrentArena is a goroutine-safe Arena. Unlike TLSFArena, which the package doc warns is
+// not safe for concurrent use, ConcurrentArena shards its free-block bookkeeping into
+// independent sub-allocators, each with its own lock, matrix, flBitmap and slBitmap, so unrelated
+// goroutines rarely contend with each other.
+type ConcurrentArena struct {
+	arena *arena.Arena
+
+	shards []concurrentShard
+
+	// next is the round-robin counter shardFor uses to pick the starting shard of each Allocate.
+	next atomic.Uint32
+}
+
+// concurrentShard is one independently-locked TLSF sub-allocator, backed by a disjoint slice
+// of the ConcurrentArena's memory. Blocks never coalesce across shard boundaries.
+type concurrentShard struct {
+	mu   sync.Mutex
+	tlsf TLSFArena
+}
+
+// NewConcurrentArena creates a goroutine-safe TLSF allocator with bytes of backing memory split
+// evenly across shards independent sub-allocators. Allocate tries a shard first and, if that
+// shard has no suitable free block, steals from the others in turn under their own locks --
+// this keeps the common case (no contention) as cheap as TLSFArena while still making progress
+// under contention, at the cost of never coalescing across shard boundaries.
+func NewConcurrentArena(bytes uint32, shards int) Arena {
+	if shards < 1 {
+		shards = 1
+	}
+
+	a := arena.NewArena()
+	c := arena.New[ConcurrentArena](a)
+	c.arena = a
+	c.shards = make([]concurrentShard, shards)
+
+	shardBytes := uint32(roundDown(int64(bytes) / int64(shards)))
+	if int64(shardBytes) < 2*BlockHeaderSize {
+		panic(ErrShardTooSmall)
+	}
+
+	raw := arena.MakeSlice[byte](a, int(shardBytes)*shards, int(shardBytes)*shards)
+	base := uintptr(unsafe.Pointer(&raw[0]))
+
+	for i := range c.shards {
+		s := &c.shards[i]
+
+		// The tiny-block tier and tagShard both repurpose bits just ahead of the pointer
+		// Allocate returns; the two tagging schemes don't compose, so shards opt out of the
+		// tier entirely rather than risk tagShard corrupting a tiny chunk's back-pointers.
+		WithoutTinyTier()(&s.tlsf)
+
+		if err := s.tlsf.installRegion(base+uintptr(i)*uintptr(shardBytes), shardBytes); err != nil {
+			panic(err)
+		}
+	}
+
+	return c
+}
+
+// shardFor picks the shard a caller should try first. Go has no portable way to read the
+// current goroutine ID, so callers are spread across shards with a fast, lock-free counter --
+// the same load-balancing effect as hashing a goroutine ID.
+func (c *ConcurrentArena) shardFor() int {
+	return int(c.next.Add(1)) % len(c.shards)
+}
+
+// Allocate allocates a block of memory with the specified size. It tries the caller's shard
+// first and, if that shard has no suitable free block, steals from the remaining shards in turn.
+func (c *ConcurrentArena) Allocate(size int64) (unsafe.Pointer, error) {
+	start := c.shardFor()
+
+	for i := 0; i < len(c.shards); i++ {
+		idx := (start + i) % len(c.shards)
+		s := &c.shards[idx]
+
+		s.mu.Lock()
+		ptr, err := s.tlsf.Allocate(size)
+		s.mu.Unlock()
+
+		if err == nil {
+			return tagShard(ptr, idx), nil
+		}
+	}
+
+	return nil, ErrBlockNotFound
+}
+
+// Free deallocates the memory block pointed to by ptr, routing it back to the shard that
+// allocated it, which was stamped into the block header by Allocate.
+func (c *ConcurrentArena) Free(ptr unsafe.Pointer) {
+	idx, ptr := untagShard(ptr)
+	s := &c.shards[idx]
+
+	s.mu.Lock()
+	s.tlsf.Free(ptr)
+	s.mu.Unlock()
+}
+
+// Reallocate resizes the memory block pointed to by ptr, routing the operation to the shard
+// that allocated it. It never moves a block across shards, even on the Allocate+Free fallback
+// path, so the returned pointer always belongs to the same shard as ptr.
+func (c *ConcurrentArena) Reallocate(ptr unsafe.Pointer, newSize int64) (unsafe.Pointer, error) {
+	idx, ptr := untagShard(ptr)
+	s := &c.shards[idx]
+
+	s.mu.Lock()
+	newPtr, err := s.tlsf.Reallocate(ptr, newSize)
+	s.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return tagShard(newPtr, idx), nil
+}
+
+// Stats returns a snapshot of the ConcurrentArena's health, aggregated across all shards.
+// FreeBlocksByClass is the concatenation of every shard's own classes, since shards never share
+// size-class free lists; Fragmentation is recomputed from the aggregated totals, not averaged.
+func (c *ConcurrentArena) Stats() Stats {
+	var agg Stats
+
+	for i := range c.shards {
+		s := &c.shards[i]
+
+		s.mu.Lock()
+		shardStats := s.tlsf.Stats()
+		s.mu.Unlock()
+
+		agg.TotalBytes += shardStats.TotalBytes
+		agg.UsedSize += shardStats.UsedSize
+		agg.FreeBytes += shardStats.FreeBytes
+		agg.UsedBlocks += shardStats.UsedBlocks
+		if shardStats.LargestFree > agg.LargestFree {
+			agg.LargestFree = shardStats.LargestFree
+		}
+		agg.FreeBlocksByClass = append(agg.FreeBlocksByClass, shardStats.FreeBlocksByClass...)
+	}
+
+	if agg.FreeBytes > 0 {
+		agg.Fragmentation = 1 - float64(agg.LargestFree)/float64(agg.FreeBytes)
+	}
+
+	return agg
+}
+
+// Dispose releases all resources associated with the ConcurrentArena.
+func (c *ConcurrentArena) Dispose() {
+	c.arena.Free()
+	c.arena = nil
+}
+
+// UsedSize returns the total amount of block size currently allocated across all shards.
+func (c *ConcurrentArena) UsedSize() int64 {
+	var total int64
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.Lock()
+		total += s.tlsf.usedSize
+		s.mu.Unlock()
+	}
+	return total
+}
+
+// tagShard stamps idx into the high bits of the block header preceding ptr, so Free can later
+// recover which shard owns it, and returns ptr unchanged.
+func tagShard(ptr unsafe.Pointer, idx int) unsafe.Pointer {
+	b := (*BlockHeader)(unsafe.Pointer(uintptr(ptr) - BlockHeaderSize))
+	b.blockSize = (b.blockSize &^ (int64(-1) << shardIDShift)) | (int64(idx) << shardIDShift)
+	return ptr
+}
+
+// untagShard recovers the shard index stamped by tagShard and clears it back out; the wrapped
+// TLSFArena's own bookkeeping only ever touches the low 32 bits and doesn't expect the rest set.
+func untagShard(ptr unsafe.Pointer) (int, unsafe.Pointer) {
+	b := (*BlockHeader)(unsafe.Pointer(uintptr(ptr) - BlockHeaderSize))
+	idx := int(uint32(b.blockSize >> shardIDShift))
+	b.blockSize &^= int64(-1) << shardIDShift
+	return idx, ptr
+}
@@ -0,0 +1,77 @@
+package tlsf
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestConcurrentArena_AllocateFreeAcrossShards(t *testing.T) {
+	a := NewConcurrentArena(64*1024, 4)
+	defer a.Dispose()
+
+	// Every shard's sentinel head/tail headers count as permanently "used" overhead (the same
+	// convention as a plain TLSFArena), so the floor to return to after freeing everything is
+	// this baseline, not zero.
+	baseline := a.UsedSize()
+
+	var ptrs []unsafe.Pointer
+	for i := 0; i < 64; i++ {
+		ptr, err := a.Allocate(128)
+		if err != nil {
+			t.Fatalf("Allocate() iteration %d error = %v", i, err)
+		}
+		ptrs = append(ptrs, ptr)
+	}
+
+	if got := a.UsedSize(); got <= baseline {
+		t.Errorf("UsedSize() after allocating = %d, want > %d", got, baseline)
+	}
+
+	for _, ptr := range ptrs {
+		a.Free(ptr)
+	}
+
+	if got := a.UsedSize(); got != baseline {
+		t.Errorf("UsedSize() after freeing everything = %d, want %d", got, baseline)
+	}
+}
+
+func TestNewConcurrentArena_PanicsOnTinyShards(t *testing.T) {
+	tests := []struct {
+		name   string
+		bytes  uint32
+		shards int
+	}{
+		{"shard rounds to 0 bytes", 100, 8},
+		{"shard too small for sentinels", 200, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewConcurrentArena(%d, %d) did not panic", tt.bytes, tt.shards)
+				}
+			}()
+
+			NewConcurrentArena(tt.bytes, tt.shards)
+		})
+	}
+}
+
+func TestConcurrentArena_Reallocate(t *testing.T) {
+	a := NewConcurrentArena(64*1024, 4)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(32)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	ptr, err = a.Reallocate(ptr, 512)
+	if err != nil {
+		t.Fatalf("Reallocate() error = %v", err)
+	}
+
+	a.Free(ptr)
+}
@@ -0,0 +1,115 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import (
+	"arena"
+	"unsafe"
+)
+
+// RegionProvider supplies the backing memory for a new region, given the size requested in bytes.
+// The returned slice's underlying array must stay alive for the lifetime of the Arena that requested it.
+type RegionProvider func(a *arena.Arena, bytes uint32) []byte
+
+// defaultRegionProvider draws region memory from the same source arena the TLSFArena itself lives in,
+// so a single Dispose call releases every region.
+func defaultRegionProvider(a *arena.Arena, bytes uint32) []byte {
+	return arena.MakeSlice[byte](a, int(bytes), int(bytes))
+}
+
+// NewGrowableArena creates a TLSF memory allocator that starts with initial bytes of backing memory
+// and transparently requests additional regions as needed, rather than failing with ErrBlockNotFound
+// once the initial region is exhausted.
+//
+// grow computes the size of the next region to request, given the minimum number of bytes needed to
+// satisfy the allocation that triggered the growth. A typical policy requests twice that minimum:
+//
+//	grow := func(min int64) int64 {
+//	    return min * 2
+//	}
+//
+// max caps the combined size of all regions; once reached, Allocate falls back to returning
+// ErrBlockNotFound instead of requesting further growth. A max of 0 means unbounded.
+func NewGrowableArena(initial, max uint32, grow func(min int64) int64) Arena {
+	a := arena.NewArena()
+	tlsf := arena.New[TLSFArena](a)
+	tlsf.arena = a
+	tlsf.regionProvider = defaultRegionProvider
+	tlsf.maxBytes = max
+	tlsf.grow = grow
+
+	if err := tlsf.addRegion(initial); err != nil {
+		panic(err)
+	}
+
+	return tlsf
+}
+
+// addRegion requests bytes of new backing memory from the region provider and installs it.
+// It is how a growable arena adds capacity on demand; NewArena uses it for its one and only region.
+func (t *TLSFArena) addRegion(bytes uint32) error {
+	raw := t.regionProvider(t.arena, bytes)
+	if len(raw) == 0 {
+		return ErrBlockNotFound
+	}
+
+	return t.installRegion(uintptr(unsafe.Pointer(&raw[0])), bytes)
+}
+
+// installRegion installs sentinel head/tail block headers over bytes of memory starting at base,
+// exactly the way NewArena always has, and splices the resulting free block into the
+// matrix/flBitmap/slBitmap so findSuitableBlock can see it.
+func (t *TLSFArena) installRegion(base uintptr, bytes uint32) error {
+	b := (*FreeBlockHeader)(unsafe.Pointer(base))
+
+	// Initial block size = Total size - 32 bytes (initial block header + last block header)
+	b.blockSize = roundDown(int64(bytes) - (2 * BlockHeaderSize))
+	b.setBlockStatus(PreviousBlockUsed | FreeBlock)
+
+	lb := (*BlockHeader)(unsafe.Add(b.getPtr(), b.getBlockSize()))
+	lb.setBlockStatus(PreviousBlockFree | UsedBlock)
+	lb.prevHeader = (*BlockHeader)(unsafe.Pointer(b))
+
+	t.regions = append(t.regions, region{base: base, size: int64(bytes)})
+
+	usedBefore := t.usedSize
+	t.Free(b.getPtr())
+	t.usedSize = usedBefore + int64(bytes) - b.getBlockSize()
+
+	return nil
+}
+
+// growFor requests a new region large enough to satisfy an allocation of at least size bytes,
+// honoring maxBytes, and splices it into the matrix for findSuitableBlock to pick up next.
+func (t *TLSFArena) growFor(size int64) error {
+	min := size + 2*BlockHeaderSize
+
+	want := t.grow(min)
+	if want < min {
+		want = min
+	}
+
+	if t.maxBytes > 0 {
+		if room := int64(t.maxBytes) - t.totalBytes(); want > room {
+			want = room
+		}
+		if want < min {
+			return ErrBlockNotFound
+		}
+	}
+
+	return t.addRegion(uint32(want))
+}
+
+// totalBytes returns the combined size of every region currently backing the arena.
+func (t *TLSFArena) totalBytes() int64 {
+	var total int64
+	for _, r := range t.regions {
+		total += r.size
+	}
+	return total
+}
@@ -0,0 +1,31 @@
+package tlsf
+
+import "testing"
+
+func TestNewGrowableArena_GrowsOnExhaustion(t *testing.T) {
+	a := NewGrowableArena(256, 0, func(min int64) int64 { return min * 2 })
+	defer a.Dispose()
+
+	for i := 0; i < 5; i++ {
+		if _, err := a.Allocate(64); err != nil {
+			t.Fatalf("Allocate() iteration %d error = %v", i, err)
+		}
+	}
+}
+
+func TestNewGrowableArena_RespectsMax(t *testing.T) {
+	a := NewGrowableArena(256, 512, func(min int64) int64 { return min * 2 })
+	defer a.Dispose()
+
+	var lastErr error
+	for i := 0; i < 50; i++ {
+		if _, err := a.Allocate(64); err != nil {
+			lastErr = err
+			break
+		}
+	}
+
+	if lastErr != ErrBlockNotFound {
+		t.Fatalf("Allocate() past max = %v, want ErrBlockNotFound", lastErr)
+	}
+}
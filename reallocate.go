@@ -0,0 +1,130 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import "unsafe"
+
+// Reallocate resizes the memory block pointed to by ptr to newSize bytes.
+// It avoids copying when possible: shrinking splits the tail off in place, and growing first
+// tries to absorb the physical next block if it is free and large enough. Only when neither
+// fast path applies does it fall back to Allocate, copy the old contents, and Free the old block.
+//
+// Parameters:
+//   - ptr: A pointer to the memory block to be resized.
+//   - newSize: The desired size of the memory block, in bytes.
+//
+// Returns:
+//   - unsafe.Pointer: A pointer to the resized memory block.
+//   - error: An error if the allocation fails, or nil if successful.
+func (t *TLSFArena) Reallocate(ptr unsafe.Pointer, newSize int64) (unsafe.Pointer, error) {
+	if !t.tinyDisabled {
+		if chunk, capacity, ok := tinyTagOf(ptr); ok {
+			return t.reallocateTiny(ptr, chunk, capacity, newSize)
+		}
+	}
+
+	if newSize < MinBlockSize {
+		newSize = MinBlockSize
+	} else {
+		newSize = roundUp(newSize)
+	}
+
+	b := (*FreeBlockHeader)(unsafe.Pointer(uintptr(ptr) - BlockHeaderSize))
+	cur := b.getBlockSize()
+
+	if newSize <= cur {
+		return t.shrinkInPlace(b, newSize), nil
+	}
+
+	if p, ok := t.growInPlace(b, newSize); ok {
+		return p, nil
+	}
+
+	newPtr, err := t.Allocate(newSize)
+	if err != nil {
+		return nil, err
+	}
+	copy(unsafe.Slice((*byte)(newPtr), cur), unsafe.Slice((*byte)(ptr), cur))
+	t.Free(ptr)
+
+	return newPtr, nil
+}
+
+// shrinkInPlace splits the tail off b once it is larger than newSize, exactly the way Allocate
+// splits a found free block, and inserts the split-off tail back into the matrix.
+func (t *TLSFArena) shrinkInPlace(b *FreeBlockHeader, newSize int64) unsafe.Pointer {
+	cur := b.getBlockSize()
+	tmpSize := cur - newSize
+	if tmpSize < BlockHeaderSize {
+		return b.getPtr()
+	}
+	tmpSize -= BlockHeaderSize
+
+	next := (*BlockHeader)(unsafe.Add(b.getPtr(), cur))
+
+	b2 := (*FreeBlockHeader)(unsafe.Add(b.getPtr(), newSize))
+	b2.blockSize = tmpSize
+	b2.setBlockStatus(PreviousBlockUsed | FreeBlock)
+	next.prevHeader = (*BlockHeader)(unsafe.Pointer(b2))
+	next.setBlockStatus(PreviousBlockFree)
+
+	b.blockSize = newSize | (b.blockSize & 0x2)
+
+	t.removeSize(b2)
+
+	var fl, sl int64
+	determineLevels(tmpSize, &fl, &sl)
+	t.insertBlock(b2, fl, sl)
+
+	return b.getPtr()
+}
+
+// growInPlace attempts to satisfy newSize by absorbing all or part of the physical next block
+// when it is free and the combined size suffices, avoiding a copy. It reports whether it succeeded.
+func (t *TLSFArena) growInPlace(b *FreeBlockHeader, newSize int64) (unsafe.Pointer, bool) {
+	cur := b.getBlockSize()
+
+	next := (*BlockHeader)(unsafe.Add(b.getPtr(), cur))
+	if !next.isFree() {
+		return nil, false
+	}
+
+	nextSize := next.getBlockSize()
+	combined := cur + BlockHeaderSize + nextSize
+	if combined < newSize {
+		return nil, false
+	}
+
+	var fl, sl int64
+	determineLevels(nextSize, &fl, &sl)
+	t.extractBlock((*FreeBlockHeader)(unsafe.Pointer(next)), fl, sl)
+	t.usedSize += nextSize + BlockHeaderSize
+
+	afterNext := (*BlockHeader)(unsafe.Add(b.getPtr(), combined))
+	tmpSize := combined - newSize
+
+	if tmpSize >= BlockHeaderSize {
+		tmpSize -= BlockHeaderSize
+
+		b2 := (*FreeBlockHeader)(unsafe.Add(b.getPtr(), newSize))
+		b2.blockSize = tmpSize
+		b2.setBlockStatus(PreviousBlockUsed | FreeBlock)
+		afterNext.prevHeader = (*BlockHeader)(unsafe.Pointer(b2))
+
+		b.blockSize = newSize | (b.blockSize & 0x2)
+
+		determineLevels(tmpSize, &fl, &sl)
+		t.insertBlock(b2, fl, sl)
+
+		t.usedSize -= tmpSize + BlockHeaderSize
+	} else {
+		afterNext.blockSize &^= PreviousBlockFree
+		b.blockSize = combined | (b.blockSize & 0x2)
+	}
+
+	return b.getPtr(), true
+}
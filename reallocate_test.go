@@ -0,0 +1,88 @@
+package tlsf
+
+import "testing"
+
+func TestReallocate_ShrinkInPlace(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(256)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	before := a.UsedSize()
+
+	newPtr, err := a.Reallocate(ptr, 32)
+	if err != nil {
+		t.Fatalf("Reallocate() error = %v", err)
+	}
+
+	if newPtr != ptr {
+		t.Errorf("Reallocate() shrinking moved the block, want same pointer")
+	}
+	if got := a.UsedSize(); got >= before {
+		t.Errorf("UsedSize() after shrink = %d, want < %d", got, before)
+	}
+
+	a.Free(newPtr)
+}
+
+func TestReallocate_GrowInPlaceCoalescesNextFreeBlock(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(32)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	// Allocate and immediately free the physically next block so growInPlace has a free
+	// neighbor to absorb.
+	next, err := a.Allocate(256)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	a.Free(next)
+
+	newPtr, err := a.Reallocate(ptr, 128)
+	if err != nil {
+		t.Fatalf("Reallocate() error = %v", err)
+	}
+	if newPtr != ptr {
+		t.Errorf("Reallocate() grow-in-place moved the block, want same pointer")
+	}
+
+	a.Free(newPtr)
+}
+
+func TestReallocate_FallsBackToCopy(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(32)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	b := (*byte)(ptr)
+	*b = 0xCD
+
+	// Keep the physically next block allocated so growInPlace cannot absorb it, forcing the
+	// Allocate+copy+Free fallback path.
+	guard, err := a.Allocate(32)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+	defer a.Free(guard)
+
+	newPtr, err := a.Reallocate(ptr, 4096)
+	if err != nil {
+		t.Fatalf("Reallocate() error = %v", err)
+	}
+
+	if got := *(*byte)(newPtr); got != 0xCD {
+		t.Errorf("Reallocate() first byte = %#x, want %#x", got, byte(0xCD))
+	}
+
+	a.Free(newPtr)
+}
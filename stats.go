@@ -0,0 +1,114 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import "unsafe"
+
+// FreeClass counts the outstanding free blocks in one (fl, sl) size class.
+type FreeClass struct {
+	FL, SL int64
+	Count  int
+}
+
+// Stats summarizes the health of an arena's backing memory, as returned by Arena.Stats.
+type Stats struct {
+	// TotalBytes is the combined size of every region backing the arena.
+	TotalBytes int64
+
+	// UsedSize is the total block size currently allocated (see Arena.UsedSize).
+	UsedSize int64
+
+	// FreeBytes is the combined usable size of every free block, across every size class.
+	FreeBytes int64
+
+	// LargestFree is the usable size of the single largest free block.
+	LargestFree int64
+
+	// UsedBlocks is the number of in-use physical blocks.
+	UsedBlocks int
+
+	// FreeBlocksByClass counts free blocks per (fl, sl) cell; empty cells are omitted.
+	FreeBlocksByClass []FreeClass
+
+	// Fragmentation estimates external fragmentation as 1 - LargestFree/FreeBytes.
+	// It is 0 when there is no free memory.
+	Fragmentation float64
+}
+
+// Stats summarizes allocator health: capacity, usage, per-size-class free block counts, the
+// largest free block, and an estimated external fragmentation ratio. Free-list figures are
+// computed by walking matrix[fl][sl] lists, consulting flBitmap/slBitmap to skip empty rows
+// cheaply; UsedBlocks additionally requires a full physical walk via Walk.
+func (t *TLSFArena) Stats() Stats {
+	var s Stats
+	s.UsedSize = t.usedSize
+
+	for _, r := range t.regions {
+		s.TotalBytes += r.size
+	}
+
+	flBits := int64(t.flBitmap)
+	for flBits != 0 {
+		fl := lsb(flBits)
+		flBits &^= int64(1) << uint(fl)
+
+		slBits := int64(t.slBitmap[fl])
+		for slBits != 0 {
+			sl := lsb(slBits)
+			slBits &^= int64(1) << uint(sl)
+
+			count := 0
+			for b := t.matrix[fl][sl]; b != nil; b = b.next {
+				count++
+				size := b.getBlockSize()
+				s.FreeBytes += size
+				if size > s.LargestFree {
+					s.LargestFree = size
+				}
+			}
+			s.FreeBlocksByClass = append(s.FreeBlocksByClass, FreeClass{FL: fl, SL: sl, Count: count})
+		}
+	}
+
+	t.Walk(func(_ uintptr, _ int64, free bool) bool {
+		if !free {
+			s.UsedBlocks++
+		}
+		return true
+	})
+
+	if s.FreeBytes > 0 {
+		s.Fragmentation = 1 - float64(s.LargestFree)/float64(s.FreeBytes)
+	}
+
+	return s
+}
+
+// Walk traverses every physical block of every region, in address order, calling fn with each
+// block's address, usable size, and whether it is currently free. Traversal of a region stops
+// at its sentinel tail block (always a zero-sized UsedBlock) without calling fn on it. Walk
+// returns as soon as fn returns false.
+//
+// Parameters:
+//   - fn: Called once per physical block; return false to stop walking early.
+func (t *TLSFArena) Walk(fn func(addr uintptr, size int64, free bool) bool) {
+	for _, r := range t.regions {
+		b := (*BlockHeader)(unsafe.Pointer(r.base))
+		for {
+			if !fn(uintptr(unsafe.Pointer(b)), b.getBlockSize(), b.isFree()) {
+				return
+			}
+
+			next := (*BlockHeader)(unsafe.Add(unsafe.Pointer(b), BlockHeaderSize+b.getBlockSize()))
+			if next.getBlockSize() == 0 && !next.isFree() {
+				// next is the region's sentinel tail block; this region is done.
+				break
+			}
+			b = next
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tlsf
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(256)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	s := a.Stats()
+	if s.TotalBytes == 0 {
+		t.Errorf("Stats().TotalBytes = %d, want > 0", s.TotalBytes)
+	}
+	if s.UsedSize != a.UsedSize() {
+		t.Errorf("Stats().UsedSize = %d, want %d", s.UsedSize, a.UsedSize())
+	}
+	if s.UsedBlocks != 1 {
+		t.Errorf("Stats().UsedBlocks = %d, want 1", s.UsedBlocks)
+	}
+	if s.FreeBytes == 0 {
+		t.Errorf("Stats().FreeBytes = %d, want > 0", s.FreeBytes)
+	}
+	if len(s.FreeBlocksByClass) == 0 {
+		t.Errorf("Stats().FreeBlocksByClass is empty, want at least one class")
+	}
+
+	a.Free(ptr)
+
+	s = a.Stats()
+	if s.UsedBlocks != 0 {
+		t.Errorf("Stats().UsedBlocks after freeing = %d, want 0", s.UsedBlocks)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tlsfArena := NewArena(64 * 1024)
+	defer tlsfArena.Dispose()
+
+	a := tlsfArena.(*TLSFArena)
+
+	ptr, err := a.Allocate(256)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	var sawUsed, sawFree bool
+	a.Walk(func(_ uintptr, _ int64, free bool) bool {
+		if free {
+			sawFree = true
+		} else {
+			sawUsed = true
+		}
+		return true
+	})
+
+	if !sawUsed {
+		t.Errorf("Walk() never visited a used block")
+	}
+	if !sawFree {
+		t.Errorf("Walk() never visited a free block")
+	}
+
+	var visited int
+	a.Walk(func(_ uintptr, _ int64, _ bool) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("Walk() visited %d blocks after early return, want 1", visited)
+	}
+
+	a.Free(ptr)
+}
@@ -0,0 +1,131 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import "unsafe"
+
+// Option configures a TLSFArena at construction time.
+type Option func(*TLSFArena)
+
+// WithoutTinyTier disables the tiny-block sub-allocator tier, so requests smaller than
+// TinyThreshold always go through the normal TLSF path (rounded up to MinBlockSize) instead of
+// being bump-allocated out of a shared chunk. Use it when deterministic, uniformly-tracked
+// allocations matter more than the space small requests would otherwise save.
+func WithoutTinyTier() Option {
+	return func(t *TLSFArena) {
+		t.tinyDisabled = true
+	}
+}
+
+// tinyChunkHeader sits at the very start of a tiny chunk's usable memory, immediately after its
+// own BlockHeader, and tracks how many outstanding tiny allocations still point into the chunk.
+type tinyChunkHeader struct {
+	refs int32
+}
+
+// allocateTiny bump-allocates size bytes, 8-byte aligned, out of the current tiny chunk. Each
+// slot is preceded by an 8-byte back-pointer to its owning chunk, tagged with the TinyChunk bit,
+// since a slot's address can land anywhere inside the chunk and so isn't reliably
+// BlockHeaderSize bytes past a real BlockHeader the way a normal allocation's is.
+// When the current chunk has no room left, a fresh tinyChunkSize-byte chunk is obtained through
+// the normal Allocate/findSuitableBlock path.
+func (t *TLSFArena) allocateTiny(size int64) (unsafe.Pointer, error) {
+	size = (size + (tinySlotAlign - 1)) &^ (tinySlotAlign - 1)
+	slot := size + tinySlotAlign
+
+	if t.tinyChunk == nil || t.tinyOffset+slot > tinyChunkSize {
+		ptr, err := t.Allocate(tinyChunkSize)
+		if err != nil {
+			return nil, err
+		}
+
+		hdr := (*BlockHeader)(unsafe.Pointer(uintptr(ptr) - BlockHeaderSize))
+		hdr.setBlockStatus(TinyChunk)
+
+		chunk := (*tinyChunkHeader)(ptr)
+		chunk.refs = 0
+
+		t.tinyChunk = chunk
+		t.tinyOffset = (int64(unsafe.Sizeof(tinyChunkHeader{})) + (tinySlotAlign - 1)) &^ (tinySlotAlign - 1)
+	}
+
+	chunk := t.tinyChunk
+
+	tag := uintptr(unsafe.Pointer(chunk)) | uintptr(TinyChunk)
+	if size > tinySlotAlign {
+		tag |= uintptr(tinySlotSize16)
+	}
+
+	backPtr := (*uintptr)(unsafe.Add(unsafe.Pointer(chunk), t.tinyOffset))
+	*backPtr = tag
+
+	data := unsafe.Add(unsafe.Pointer(backPtr), tinySlotAlign)
+	t.tinyOffset += slot
+	chunk.refs++
+
+	if t.tinyOffset+tinySlotAlign >= tinyChunkSize {
+		// No room left for even a zero-length slot; the next call starts a fresh chunk.
+		t.tinyChunk = nil
+	}
+
+	return data, nil
+}
+
+// reallocateTiny resizes a tiny-tier allocation. capacity is the slot's actual reserved data
+// size (8 or 16 bytes, decoded by tinyTagOf from its back-pointer tag) rather than a flat
+// MinBlockSize, since an original request of 1-8 bytes only ever reserved tinySlotAlign (8)
+// bytes and copying past that would read into the next slot's back-pointer or data.
+func (t *TLSFArena) reallocateTiny(ptr unsafe.Pointer, chunk *tinyChunkHeader, capacity, newSize int64) (unsafe.Pointer, error) {
+	newPtr, err := t.Allocate(newSize)
+	if err != nil {
+		return nil, err
+	}
+
+	copyLen := newSize
+	if copyLen > capacity {
+		copyLen = capacity
+	}
+	copy(unsafe.Slice((*byte)(newPtr), copyLen), unsafe.Slice((*byte)(ptr), copyLen))
+
+	t.freeTiny(chunk)
+
+	return newPtr, nil
+}
+
+// tinyTagOf decodes the back-pointer word stored tinySlotAlign bytes before ptr. It reports
+// whether ptr is actually a tiny-tier allocation and, if so, its owning chunk and the slot's
+// reserved data capacity. Only safe to call when the tiny tier is enabled for this arena.
+func tinyTagOf(ptr unsafe.Pointer) (chunk *tinyChunkHeader, capacity int64, ok bool) {
+	tag := *(*uintptr)(unsafe.Add(ptr, -tinySlotAlign))
+	if tag&uintptr(TinyChunk) == 0 {
+		return nil, 0, false
+	}
+
+	capacity = tinySlotAlign
+	if tag&uintptr(tinySlotSize16) != 0 {
+		capacity = tinySlotAlign * 2
+	}
+
+	return (*tinyChunkHeader)(unsafe.Pointer(tag &^ uintptr(TinyChunk|tinySlotSize16))), capacity, true
+}
+
+// freeTiny decrements the refcount of a tiny chunk and, once no outstanding allocation
+// references it, clears the TinyChunk flag on the chunk's header and frees the whole chunk back
+// through freeBlock.
+func (t *TLSFArena) freeTiny(chunk *tinyChunkHeader) {
+	chunk.refs--
+	if chunk.refs > 0 {
+		return
+	}
+
+	if t.tinyChunk == chunk {
+		t.tinyChunk = nil
+	}
+	hdr := (*BlockHeader)(unsafe.Add(unsafe.Pointer(chunk), -BlockHeaderSize))
+	hdr.blockSize &^= TinyChunk
+	t.freeBlock(unsafe.Pointer(chunk))
+}
@@ -0,0 +1,69 @@
+package tlsf
+
+import "testing"
+
+func TestTinyArena_AllocateFree(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	// The sentinel head/tail headers count as permanently "used" overhead (the same convention
+	// as a plain TLSFArena), so the floor to return to after freeing is this baseline, not zero.
+	baseline := a.UsedSize()
+
+	ptr, err := a.Allocate(4)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	if got := a.UsedSize(); got <= baseline {
+		t.Errorf("UsedSize() after allocating = %d, want > %d", got, baseline)
+	}
+
+	a.Free(ptr)
+
+	if got := a.UsedSize(); got != baseline {
+		t.Errorf("UsedSize() after freeing = %d, want %d", got, baseline)
+	}
+}
+
+func TestTinyArena_ReallocateGrowPastThreshold(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	ptr, err := a.Allocate(1)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	b := (*byte)(ptr)
+	*b = 0xAB
+
+	newPtr, err := a.Reallocate(ptr, 64)
+	if err != nil {
+		t.Fatalf("Reallocate() error = %v", err)
+	}
+
+	if got := *(*byte)(newPtr); got != 0xAB {
+		t.Errorf("Reallocate() first byte = %#x, want %#x", got, byte(0xAB))
+	}
+
+	a.Free(newPtr)
+}
+
+func TestTinyArena_WithoutTinyTier(t *testing.T) {
+	a := NewArena(64*1024, WithoutTinyTier())
+	defer a.Dispose()
+
+	baseline := a.UsedSize()
+
+	ptr, err := a.Allocate(4)
+	if err != nil {
+		t.Fatalf("Allocate() error = %v", err)
+	}
+
+	a.Free(ptr)
+
+	if got := a.UsedSize(); got != baseline {
+		t.Errorf("UsedSize() after freeing = %d, want %d", got, baseline)
+	}
+}
@@ -40,6 +40,21 @@ type Arena interface {
 	//   - ptr: A pointer to the memory block to be freed.
 	Free(ptr unsafe.Pointer)
 
+	// Reallocate resizes the memory block pointed to by ptr to newSize bytes, returning a
+	// pointer to the resized block. The contents up to the minimum of the old and new sizes
+	// are preserved, but the returned pointer may differ from ptr.
+	// The behavior is undefined if ptr is not a pointer returned by Allocate, or if it has
+	// already been freed.
+	//
+	// Parameters:
+	//   - ptr: A pointer to the memory block to be resized.
+	//   - newSize: The desired size of the memory block, in bytes.
+	//
+	// Returns:
+	//   - unsafe.Pointer: A pointer to the resized memory block.
+	//   - error: An error if the allocation fails, or nil if successful.
+	Reallocate(ptr unsafe.Pointer, newSize int64) (unsafe.Pointer, error)
+
 	// Dispose releases all resources associated with the Arena.
 	// After calling Dispose, the Arena should not be used anymore.
 	Dispose()
@@ -49,6 +64,13 @@ type Arena interface {
 	// Returns:
 	//   - int64: The total size of allocated memory in bytes.
 	UsedSize() int64
+
+	// Stats returns a snapshot of the Arena's health: capacity, usage, free-list makeup, and an
+	// estimated external fragmentation ratio.
+	//
+	// Returns:
+	//   - Stats: A summary of the Arena's current state.
+	Stats() Stats
 }
 
 // TLSFArena represents a TLSF memory allocator.
@@ -69,13 +91,44 @@ type TLSFArena struct {
 	// Each element is a pointer to a FreeBlockHeader, which is the head of a free block list.
 	matrix [RealFLI][MaxSLI]*FreeBlockHeader
 
-	// Base pointer of memory allocation source (&bytes[0])
-	head uintptr
+	// regions holds every backing memory region owned by this arena, in the order they were added.
+	// A plain TLSFArena created via NewArena always has exactly one.
+	regions []region
+
+	// regionProvider supplies the backing memory for new regions. It defaults to defaultRegionProvider,
+	// which draws from the same source arena as the TLSFArena itself.
+	regionProvider RegionProvider
+
+	// grow computes the size of the next region to request when the arena runs out of space.
+	// It is nil for arenas created via NewArena, which never grow.
+	grow func(min int64) int64
+
+	// maxBytes caps the combined size of all regions. Zero means unbounded.
+	maxBytes uint32
+
+	// tinyChunk is the chunk currently being bump-allocated into by the tiny-block tier, or nil
+	// if none has been carved out yet (or the current one just filled up).
+	tinyChunk *tinyChunkHeader
+
+	// tinyOffset is the byte offset of the next free slot within tinyChunk.
+	tinyOffset int64
+
+	// tinyDisabled disables the tiny-block tier, routing every request through the normal
+	// TLSF path regardless of size. Set via the WithoutTinyTier Option.
+	tinyDisabled bool
 
 	usedSize int64
 }
 
-// BlockHeader .
+// region describes one backing memory block owned by the arena.
+type region struct {
+	base uintptr
+	size int64
+}
+
+// BlockHeader is the fixed-size header prefixing every physical block, free or used. Only the
+// BlockSize bits of blockSize are the block's size; see blockStatus for how the remaining bits
+// are used.
 type BlockHeader struct {
 	prevHeader *BlockHeader
 	blockSize  blockStatus
@@ -131,31 +184,19 @@ func (bf *BlockHeader) isPreviousBlockFree() bool {
 }
 
 // NewArena creates a new TLSF memory allocator with the specified allocation size.
-func NewArena(allocationBytes uint32) Arena {
-
-	// 1. Initialize the source Arena and allocate memory
+func NewArena(allocationBytes uint32, opts ...Option) Arena {
 	a := arena.NewArena()
 	tlsf := arena.New[TLSFArena](a)
-	bytes := arena.MakeSlice[byte](a, int(allocationBytes), int(allocationBytes))
 	tlsf.arena = a
-	tlsf.head = uintptr(unsafe.Pointer(&bytes[0]))
-
-	// 2. Create the initial block
-	b := (*FreeBlockHeader)(unsafe.Pointer(tlsf.head))
-
-	// Initial block size = Total size - 32 bytes (initial block header + last block header)
-	b.blockSize = roundDown(int64(allocationBytes) - (2 * BlockHeaderSize))
-	b.setBlockStatus(PreviousBlockUsed | FreeBlock)
-
-	// 3. initialize the last block
-	lb := (*BlockHeader)(unsafe.Add(b.getPtr(), b.getBlockSize()))
-	lb.setBlockStatus(PreviousBlockFree | UsedBlock)
-	lb.prevHeader = (*BlockHeader)(unsafe.Pointer(b))
+	tlsf.regionProvider = defaultRegionProvider
 
-	// 4. initialize the first block
-	tlsf.Free(b.getPtr())
+	for _, opt := range opts {
+		opt(tlsf)
+	}
 
-	tlsf.usedSize = int64(allocationBytes) - b.getBlockSize()
+	if err := tlsf.addRegion(allocationBytes); err != nil {
+		panic(err)
+	}
 
 	return tlsf
 }
@@ -170,6 +211,10 @@ func NewArena(allocationBytes uint32) Arena {
 //   - unsafe.Pointer: A pointer to the allocated memory block.
 //   - error: An error if the allocation fails, or nil if successful.
 func (t *TLSFArena) Allocate(size int64) (unsafe.Pointer, error) {
+	if !t.tinyDisabled && size > 0 && size < TinyThreshold {
+		return t.allocateTiny(size)
+	}
+
 	// Round up to 16 bytes if less than 16,
 	// otherwise round up to the nearest multiple of 16 bytes.
 	if size < (MinBlockSize) {
@@ -184,7 +229,18 @@ func (t *TLSFArena) Allocate(size int64) (unsafe.Pointer, error) {
 
 	b := t.findSuitableBlock(&fl, &sl)
 	if b == nil {
-		return nil, ErrBlockNotFound
+		if t.grow == nil {
+			return nil, ErrBlockNotFound
+		}
+		if err := t.growFor(size); err != nil {
+			return nil, err
+		}
+		// findSuitableBlock mutates fl/sl even on a miss (e.g. leaving fl at -1 when no bit is
+		// set), so recompute them for size from scratch rather than reusing the stale pair.
+		determineLevels(size, &fl, &sl)
+		if b = t.findSuitableBlock(&fl, &sl); b == nil {
+			return nil, ErrBlockNotFound
+		}
 	}
 
 	t.extractBlockHdr(b, fl, sl)
@@ -226,6 +282,21 @@ func (t *TLSFArena) Allocate(size int64) (unsafe.Pointer, error) {
 // Parameters:
 //   - ptr: A pointer to the memory block to be freed.
 func (t *TLSFArena) Free(ptr unsafe.Pointer) {
+	if !t.tinyDisabled {
+		if chunk, _, ok := tinyTagOf(ptr); ok {
+			t.freeTiny(chunk)
+			return
+		}
+	}
+
+	t.freeBlock(ptr)
+}
+
+// freeBlock runs the normal TLSF free path: coalescing with neighbors and reinserting the
+// resulting block into the matrix. It is also how the tiny-block tier releases a chunk whose
+// refcount has hit zero, without re-entering Free's tiny-pointer check against that chunk's own
+// header.
+func (t *TLSFArena) freeBlock(ptr unsafe.Pointer) {
 	b := (*FreeBlockHeader)(unsafe.Pointer(uintptr(ptr) - BlockHeaderSize))
 	b.setBlockStatus(FreeBlock)
 
@@ -254,7 +325,8 @@ func (t *TLSFArena) Free(ptr unsafe.Pointer) {
 	t.insertBlock(b, fl, sl)
 }
 
-// Dispose releases all resources associated with the Arena.
+// Dispose releases all resources associated with the Arena, including every region
+// added on demand by a growable arena.
 func (t *TLSFArena) Dispose() {
 	t.arena.Free()
 	t.arena = nil
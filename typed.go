@@ -0,0 +1,89 @@
+/* This program is free software. It comes without any warranty, to
+ * the extent permitted by applicable law. You can redistribute it
+ * and/or modify it under the terms of the Do What The Fuck You Want
+ * To Public License, Version 2, as published by Sam Hocevar. See
+ * http://sam.zoy.org/wtfpl/COPYING for more details. */
+
+package tlsf
+
+import "unsafe"
+
+// New allocates space for a single, zero-valued T from a.
+//
+// Parameters:
+//   - a: The Arena to allocate from.
+//
+// Returns:
+//   - *T: A pointer to the allocated value.
+//   - error: An error if the allocation fails, or nil if successful.
+func New[T any](a Arena) (*T, error) {
+	var zero T
+	ptr, err := a.Allocate(int64(unsafe.Sizeof(zero)))
+	if err != nil {
+		return nil, err
+	}
+	p := (*T)(ptr)
+	*p = zero
+	return p, nil
+}
+
+// MakeSlice allocates a []T of the given length and capacity from a.
+//
+// Parameters:
+//   - a: The Arena to allocate from.
+//   - len: The length of the returned slice.
+//   - cap: The capacity of the returned slice; the backing memory is sized for cap elements.
+//
+// Returns:
+//   - []T: A slice backed by memory from a.
+//   - error: An error if the allocation fails, or nil if successful.
+func MakeSlice[T any](a Arena, len, cap int) ([]T, error) {
+	if len < 0 || cap < 0 || len > cap {
+		panic("tlsf: invalid len/cap")
+	}
+	if cap == 0 {
+		return []T{}, nil
+	}
+
+	var zero T
+	ptr, err := a.Allocate(int64(unsafe.Sizeof(zero)) * int64(cap))
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*T)(ptr), cap)[:len], nil
+}
+
+// FreeTyped deallocates the memory backing p, previously returned by New or MakeSlice.
+// The behavior is undefined if p was not returned by New or MakeSlice for a, or if it
+// has already been freed.
+func FreeTyped[T any](a Arena, p *T) {
+	a.Free(unsafe.Pointer(p))
+}
+
+// Handle is an opaque allocation token for a value of type T. It pairs the allocated
+// pointer with the Arena it came from, so it can be freed without retaining the Arena
+// separately -- useful when embedding allocations in data structures.
+type Handle[T any] struct {
+	ptr   *T
+	arena Arena
+}
+
+// NewHandle allocates a single, zero-valued T from a and returns a Handle to it.
+func NewHandle[T any](a Arena) (Handle[T], error) {
+	ptr, err := New[T](a)
+	if err != nil {
+		return Handle[T]{}, err
+	}
+	return Handle[T]{ptr: ptr, arena: a}, nil
+}
+
+// Get returns the pointer to the value held by the handle.
+func (h Handle[T]) Get() *T {
+	return h.ptr
+}
+
+// Free deallocates the memory backing the handle.
+// The behavior is undefined if the handle has already been freed.
+func (h Handle[T]) Free() {
+	h.arena.Free(unsafe.Pointer(h.ptr))
+}
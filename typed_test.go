@@ -0,0 +1,96 @@
+package tlsf
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	p, err := New[int64](a)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if *p != 0 {
+		t.Errorf("New() = %d, want zero value", *p)
+	}
+
+	*p = 42
+	if *p != 42 {
+		t.Errorf("*p = %d, want 42", *p)
+	}
+
+	FreeTyped(a, p)
+}
+
+func TestMakeSlice(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	s, err := MakeSlice[int32](a, 4, 8)
+	if err != nil {
+		t.Fatalf("MakeSlice() error = %v", err)
+	}
+	if got, want := len(s), 4; got != want {
+		t.Errorf("len(s) = %d, want %d", got, want)
+	}
+
+	for i := range s {
+		s[i] = int32(i)
+	}
+	for i := range s {
+		if s[i] != int32(i) {
+			t.Errorf("s[%d] = %d, want %d", i, s[i], i)
+		}
+	}
+}
+
+func TestMakeSlice_ZeroCapDoesNotLeak(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	baseline := a.UsedSize()
+
+	for i := 0; i < 5; i++ {
+		s, err := MakeSlice[int32](a, 0, 0)
+		if err != nil {
+			t.Fatalf("MakeSlice() iteration %d error = %v", i, err)
+		}
+		if len(s) != 0 {
+			t.Errorf("len(s) = %d, want 0", len(s))
+		}
+	}
+
+	if got := a.UsedSize(); got != baseline {
+		t.Errorf("UsedSize() after MakeSlice(0, 0) calls = %d, want %d", got, baseline)
+	}
+}
+
+func TestMakeSlice_InvalidLenCap(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MakeSlice() with len > cap did not panic")
+		}
+	}()
+
+	_, _ = MakeSlice[int32](a, 8, 4)
+}
+
+func TestHandle(t *testing.T) {
+	a := NewArena(64 * 1024)
+	defer a.Dispose()
+
+	h, err := NewHandle[int64](a)
+	if err != nil {
+		t.Fatalf("NewHandle() error = %v", err)
+	}
+
+	*h.Get() = 7
+	if got := *h.Get(); got != 7 {
+		t.Errorf("h.Get() = %d, want 7", got)
+	}
+
+	h.Free()
+}